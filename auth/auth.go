@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	jwt "github.com/dgrijalva/jwt-go"
+)
+
+type contextKey string
+
+// UsernameContextKey is the context key the authenticated username is stored under.
+const UsernameContextKey contextKey = "username"
+
+// Rights maps an HTTP method to the list of path patterns a token is allowed
+// to call. A single path segment may be wildcarded with "*".
+type Rights map[string][]string
+
+// Claims is the payload carried by an API token.
+type Claims struct {
+	Username string `json:"username"`
+	Rights   Rights `json:"rights"`
+	jwt.StandardClaims
+}
+
+// NewMiddleware returns http middleware that validates the Authorization bearer
+// token against secret using HS256, and checks the request's method and path
+// against the token's rights before letting the request through.
+func NewMiddleware(secret []byte) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, err := authenticate(r, secret)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusUnauthorized)
+				return
+			}
+
+			if !claims.Rights.allows(r.Method, r.URL.Path) {
+				http.Error(w, "token does not have rights for this request", http.StatusForbidden)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UsernameContextKey, claims.Username)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func authenticate(r *http.Request, secret []byte) (*Claims, error) {
+	header := r.Header.Get("Authorization")
+
+	if !strings.HasPrefix(header, "Bearer ") {
+		return nil, errors.New("missing or malformed Authorization header")
+	}
+
+	tokenString := strings.TrimPrefix(header, "Bearer ")
+
+	claims := &Claims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return secret, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+func (rights Rights) allows(method string, path string) bool {
+	for _, pattern := range rights[method] {
+		if pathMatches(pattern, path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func pathMatches(pattern string, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+
+	for i, segment := range patternSegments {
+		if segment == "*" {
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
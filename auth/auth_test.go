@@ -0,0 +1,132 @@
+package auth_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+
+	. "github.com/Financial-Times/draft-content-suggestions/auth"
+	"github.com/Financial-Times/draft-content-suggestions/auth/tokens"
+)
+
+var secret = []byte("test-secret")
+
+func passThroughHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func newRequest(method string, path string, bearer string) *http.Request {
+	r := httptest.NewRequest(method, path, nil)
+	if bearer != "" {
+		r.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	return r
+}
+
+func TestMiddleware_MissingHeader(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodGet, "/drafts/content/some-uuid/suggestions", "")
+
+	NewMiddleware(secret)(passThroughHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMiddleware_InvalidSignature(t *testing.T) {
+	rights := Rights{http.MethodGet: {"/drafts/content/*/suggestions"}}
+	token, err := tokens.Generate(secret, "a-user", rights, time.Hour)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodGet, "/drafts/content/some-uuid/suggestions", token)
+
+	NewMiddleware([]byte("a-different-secret"))(passThroughHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMiddleware_ExpiredToken(t *testing.T) {
+	rights := Rights{http.MethodGet: {"/drafts/content/*/suggestions"}}
+	token, err := tokens.Generate(secret, "a-user", rights, -time.Hour)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodGet, "/drafts/content/some-uuid/suggestions", token)
+
+	NewMiddleware(secret)(passThroughHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMiddleware_MethodNotInRights(t *testing.T) {
+	rights := Rights{http.MethodGet: {"/drafts/content/*/suggestions"}}
+	token, err := tokens.Generate(secret, "a-user", rights, time.Hour)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodPost, "/drafts/content/some-uuid/suggestions", token)
+
+	NewMiddleware(secret)(passThroughHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMiddleware_PathNotMatched(t *testing.T) {
+	rights := Rights{http.MethodGet: {"/drafts/content/*/suggestions"}}
+	token, err := tokens.Generate(secret, "a-user", rights, time.Hour)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodGet, "/drafts/content/some-uuid/other", token)
+
+	NewMiddleware(secret)(passThroughHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMiddleware_SuccessfulPassThrough(t *testing.T) {
+	rights := Rights{http.MethodGet: {"/drafts/content/*/suggestions"}}
+	token, err := tokens.Generate(secret, "a-user", rights, time.Hour)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodGet, "/drafts/content/some-uuid/suggestions", token)
+
+	var capturedUsername interface{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedUsername = r.Context().Value(UsernameContextKey)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	NewMiddleware(secret)(handler).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "a-user", capturedUsername)
+}
+
+func TestMiddleware_WrongSigningMethod(t *testing.T) {
+	rights := Rights{http.MethodGet: {"/drafts/content/*/suggestions"}}
+	claims := Claims{
+		Username: "a-user",
+		Rights:   rights,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	tokenString, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	req := newRequest(http.MethodGet, "/drafts/content/some-uuid/suggestions", tokenString)
+
+	NewMiddleware(secret)(passThroughHandler()).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
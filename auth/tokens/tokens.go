@@ -0,0 +1,30 @@
+// Package tokens provides a small helper for minting API tokens understood by
+// the auth package's middleware. It is intended for tooling (e.g. provisioning
+// credentials for a new consumer), not for production request handling.
+package tokens
+
+import (
+	"time"
+
+	jwt "github.com/dgrijalva/jwt-go"
+
+	"github.com/Financial-Times/draft-content-suggestions/auth"
+)
+
+// Generate creates a signed HS256 JWT for username, granting it rights, that
+// expires after ttl.
+func Generate(secret []byte, username string, rights auth.Rights, ttl time.Duration) (string, error) {
+	now := time.Now()
+
+	claims := auth.Claims{
+		Username: username,
+		Rights:   rights,
+		StandardClaims: jwt.StandardClaims{
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
+		},
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(secret)
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Financial-Times/draft-content-suggestions/process"
+	"github.com/Financial-Times/draft-content-suggestions/queue"
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	process.Run(newSchedulerProcess())
+}
+
+// schedulerProcess is the process.Process implementation for
+// suggestions-scheduler: it accepts bulk suggestions jobs over HTTP,
+// publishes them to the broker, and serves their status as results arrive
+// back from workers.
+type schedulerProcess struct {
+	brokerURL *string
+
+	broker  queue.Broker
+	store   queue.Store
+	results <-chan queue.ResultDelivery
+}
+
+func newSchedulerProcess() *schedulerProcess {
+	return &schedulerProcess{}
+}
+
+func (p *schedulerProcess) Name() string {
+	return "suggestions-scheduler"
+}
+
+func (p *schedulerProcess) Description() string {
+	return "Schedules asynchronous bulk suggestions jobs."
+}
+
+func (p *schedulerProcess) Flags() []process.Flag {
+	return []process.Flag{
+		{Name: "broker-url", Value: "",
+			Desc: "AMQP URL of the RabbitMQ broker used to distribute suggestions jobs", EnvVar: "BROKER_URL", Target: &p.brokerURL},
+	}
+}
+
+func (p *schedulerProcess) Provide(ctx context.Context) ([]process.Provider, error) {
+	broker, err := newBroker(*p.brokerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := broker.ConsumeResults(ctx)
+	if err != nil {
+		broker.Close()
+		return nil, err
+	}
+
+	p.broker = broker
+	p.store = queue.NewMemoryStore()
+	p.results = results
+
+	return []process.Provider{brokerProvider{broker}}, nil
+}
+
+func (p *schedulerProcess) Subscribers() []process.SubscriberDef {
+	return []process.SubscriberDef{
+		{Name: "results-collector", Run: p.collectResults},
+	}
+}
+
+// collectResults drains the results queue into the store so polled GETs can
+// observe completed work as it arrives, until the results channel closes or
+// ctx is cancelled.
+func (p *schedulerProcess) collectResults(ctx context.Context) error {
+	for {
+		select {
+		case delivery, ok := <-p.results:
+			if !ok {
+				return nil
+			}
+			p.store.AddResult(delivery.Result)
+			delivery.Ack()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (p *schedulerProcess) HTTPHandler() http.Handler {
+	handler := &jobsHandler{broker: p.broker, store: p.store}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/drafts/content/suggestions/jobs", handler.createJob).Methods(http.MethodPost)
+	router.HandleFunc("/drafts/content/suggestions/jobs/{id}", handler.getJob).Methods(http.MethodGet)
+	return router
+}
+
+func (p *schedulerProcess) HealthChecks() []fthealth.Check {
+	return []fthealth.Check{
+		{
+			ID:               "check-broker-connectivity",
+			Name:             "Check connectivity to the suggestions broker",
+			Severity:         1,
+			BusinessImpact:   "Bulk suggestions jobs cannot be scheduled.",
+			TechnicalSummary: "suggestions-scheduler could not connect to the message broker.",
+			PanicGuide:       "https://runbooks.ftops.tech/draft-content-suggestions",
+			Checker: func() (string, error) {
+				if !p.broker.IsConnected() {
+					return "", errors.New("not connected to the message broker")
+				}
+				return "ok", nil
+			},
+		},
+	}
+}
+
+func newBroker(brokerURL string) (queue.Broker, error) {
+	if brokerURL == "" {
+		return queue.NewMemoryBroker(256), nil
+	}
+	return queue.NewAMQPBroker(brokerURL)
+}
+
+type jobsRequest struct {
+	UUIDs []string `json:"uuids"`
+}
+
+type jobsHandler struct {
+	broker queue.Broker
+	store  queue.Store
+}
+
+func (h *jobsHandler) createJob(w http.ResponseWriter, r *http.Request) {
+	var request jobsRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.UUIDs) == 0 {
+		http.Error(w, "request body must be a JSON object with a non-empty uuids array", http.StatusBadRequest)
+		return
+	}
+
+	job := queue.Job{ID: uuid.NewV4().String(), UUIDs: request.UUIDs}
+	h.store.Put(job)
+
+	if err := h.broker.PublishJob(r.Context(), job); err != nil {
+		log.WithError(err).WithField("jobId", job.ID).Error("Failed to publish suggestions job")
+		http.Error(w, "failed to schedule job", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"id": job.ID})
+}
+
+func (h *jobsHandler) getJob(w http.ResponseWriter, r *http.Request) {
+	id := mux.Vars(r)["id"]
+
+	state, found := h.store.Get(id)
+	if !found {
+		http.Error(w, "no job found for id: "+id, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(state)
+}
+
+// brokerProvider adapts a queue.Broker to process.Provider, so Run closes its
+// connection on shutdown.
+type brokerProvider struct {
+	broker queue.Broker
+}
+
+func (b brokerProvider) Name() string { return "broker" }
+func (b brokerProvider) Close() error { return b.broker.Close() }
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Financial-Times/draft-content-suggestions/draft"
+	"github.com/Financial-Times/draft-content-suggestions/process"
+	"github.com/Financial-Times/draft-content-suggestions/queue"
+	"github.com/Financial-Times/draft-content-suggestions/suggestions"
+	"github.com/Financial-Times/go-ft-http/fthttp"
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	process.Run(newWorkerProcess())
+}
+
+// workerProcess is the process.Process implementation for suggestions-worker:
+// it consumes bulk suggestions jobs off the broker and writes results back to
+// it. It has no business HTTP endpoints of its own.
+type workerProcess struct {
+	brokerURL               *string
+	concurrency             *string
+	draftContentEndpoint    *string
+	draftContentGtgEndpoint *string
+	suggestionsEndpoint     *string
+	suggestionsGtgEndpoint  *string
+	suggestionsAuthMode     *string
+	suggestionsAPIKey       *string
+	suggestionsUsername     *string
+	suggestionsPassword     *string
+	suggestionsXPolicies    *string
+
+	broker      queue.Broker
+	contentAPI  draft.ContentAPI
+	umbrellaAPI suggestions.UmbrellaAPI
+	jobs        <-chan queue.JobDelivery
+	concurrent  int
+}
+
+func newWorkerProcess() *workerProcess {
+	return &workerProcess{}
+}
+
+func (p *workerProcess) Name() string {
+	return "suggestions-worker"
+}
+
+func (p *workerProcess) Description() string {
+	return "Consumes bulk suggestions jobs and writes results back to the broker."
+}
+
+func (p *workerProcess) Flags() []process.Flag {
+	return []process.Flag{
+		{Name: "broker-url", Value: "",
+			Desc: "AMQP URL of the RabbitMQ broker used to distribute suggestions jobs", EnvVar: "BROKER_URL", Target: &p.brokerURL},
+		{Name: "concurrency", Value: "4",
+			Desc: "Number of goroutines concurrently processing UUIDs off the jobs queue", EnvVar: "WORKER_CONCURRENCY", Target: &p.concurrency},
+		{Name: "draft-content-endpoint", Value: "http://draft-content-public-read:8080/content",
+			Desc: "Endpoint for Draft Content API", EnvVar: "DRAFT_CONTENT_ENDPOINT", Target: &p.draftContentEndpoint},
+		{Name: "draft-content-gtg-endpoint", Value: "http://draft-content-public-read:8080/__gtg",
+			Desc: "GTG Endpoint for Draft Content API", EnvVar: "DRAFT_CONTENT_GTG_ENDPOINT", Target: &p.draftContentGtgEndpoint},
+		{Name: "suggestions-umbrella-endpoint", Value: "http://test.api.ft.com/content/suggest",
+			Desc: "Endpoint for Suggestions Umbrella", EnvVar: "SUGGESTIONS_ENDPOINT", Target: &p.suggestionsEndpoint},
+		{Name: "suggestions-umbrella-gtg-endpoint", Value: "http://test.api.ft.com/content/suggest/__gtg",
+			Desc: "GTG Endpoint for Suggestions Umbrella", EnvVar: "SUGGESTIONS_GTG_ENDPOINT", Target: &p.suggestionsGtgEndpoint},
+		{Name: "auth-mode", Value: string(suggestions.AuthModeAPIKey),
+			Desc: "Authentication mode for Suggestions Umbrella requests: api-key or basic", EnvVar: "SUGGESTIONS_AUTH_MODE", Target: &p.suggestionsAuthMode},
+		{Name: "suggestions-api-key", Value: "",
+			Desc: "API key to access Suggestions Umbrella (used when auth-mode is api-key)", EnvVar: "SUGGESTIONS_API_KEY", Target: &p.suggestionsAPIKey},
+		{Name: "suggestions-username", Value: "",
+			Desc: "Username to access Suggestions Umbrella (used when auth-mode is basic)", EnvVar: "SUGGESTIONS_USERNAME", Target: &p.suggestionsUsername},
+		{Name: "suggestions-password", Value: "",
+			Desc: "Password to access Suggestions Umbrella (used when auth-mode is basic)", EnvVar: "SUGGESTIONS_PASSWORD", Target: &p.suggestionsPassword},
+		{Name: "suggestions-x-policies", Value: "",
+			Desc: "Comma-separated list of x-policy values to forward on every Suggestions Umbrella request", EnvVar: "SUGGESTIONS_X_POLICIES", Target: &p.suggestionsXPolicies},
+	}
+}
+
+func (p *workerProcess) Provide(ctx context.Context) ([]process.Provider, error) {
+	client := fthttp.NewClientBuilder().
+		WithTimeout(10*time.Second).
+		WithSysInfo("PAC", p.Name()).
+		Build()
+
+	contentAPI, err := draft.NewContentAPI(*p.draftContentEndpoint, *p.draftContentGtgEndpoint, client)
+	if err != nil {
+		return nil, err
+	}
+
+	var xPolicies []string
+	if *p.suggestionsXPolicies != "" {
+		xPolicies = strings.Split(*p.suggestionsXPolicies, ",")
+	}
+
+	umbrellaAPI, err := suggestions.NewUmbrellaAPI(*p.suggestionsEndpoint, *p.suggestionsGtgEndpoint, suggestions.AuthMode(*p.suggestionsAuthMode),
+		*p.suggestionsAPIKey, *p.suggestionsUsername, *p.suggestionsPassword, xPolicies, client)
+	if err != nil {
+		return nil, err
+	}
+
+	broker, err := newBroker(*p.brokerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := broker.ConsumeJobs(ctx)
+	if err != nil {
+		broker.Close()
+		return nil, err
+	}
+
+	concurrent, err := strconv.Atoi(*p.concurrency)
+	if err != nil {
+		broker.Close()
+		return nil, fmt.Errorf("invalid concurrency %q: %v", *p.concurrency, err)
+	}
+
+	p.contentAPI = contentAPI
+	p.umbrellaAPI = umbrellaAPI
+	p.broker = broker
+	p.jobs = jobs
+	p.concurrent = concurrent
+
+	return []process.Provider{brokerProvider{broker}}, nil
+}
+
+func (p *workerProcess) Subscribers() []process.SubscriberDef {
+	subscribers := make([]process.SubscriberDef, p.concurrent)
+	for i := 0; i < p.concurrent; i++ {
+		subscribers[i] = process.SubscriberDef{
+			Name: fmt.Sprintf("job-processor-%d", i),
+			Run:  p.consumeJobs,
+		}
+	}
+	return subscribers
+}
+
+// consumeJobs pulls UUIDs off the jobs queue, fetches draft content and
+// suggestions for them, and publishes the outcome back onto the results
+// queue, until the jobs channel closes or ctx is cancelled.
+func (p *workerProcess) consumeJobs(ctx context.Context) error {
+	for {
+		select {
+		case delivery, ok := <-p.jobs:
+			if !ok {
+				return nil
+			}
+			if err := processJob(p.broker, p.contentAPI, p.umbrellaAPI, delivery.Job); err != nil {
+				delivery.Nack()
+			} else {
+				delivery.Ack()
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (p *workerProcess) HTTPHandler() http.Handler {
+	return mux.NewRouter()
+}
+
+func (p *workerProcess) HealthChecks() []fthealth.Check {
+	return []fthealth.Check{
+		{
+			ID:               "check-broker-connectivity",
+			Name:             "Check connectivity to the suggestions broker",
+			Severity:         1,
+			BusinessImpact:   "Bulk suggestions jobs cannot be processed.",
+			TechnicalSummary: "suggestions-worker could not connect to the message broker.",
+			PanicGuide:       "https://runbooks.ftops.tech/draft-content-suggestions",
+			Checker: func() (string, error) {
+				if !p.broker.IsConnected() {
+					return "", errors.New("not connected to the message broker")
+				}
+				return "ok", nil
+			},
+		},
+	}
+}
+
+func newBroker(brokerURL string) (queue.Broker, error) {
+	if brokerURL == "" {
+		return queue.NewMemoryBroker(256), nil
+	}
+	return queue.NewAMQPBroker(brokerURL)
+}
+
+// processJob fetches draft content and suggestions for every UUID in job and
+// publishes the outcome back onto the results queue. It returns an error, and
+// leaves the job to be redelivered, only when publishing a result fails -
+// per-UUID fetch/suggestion failures are recorded in Result.Error and still
+// count as successfully processed.
+func processJob(broker queue.Broker, contentAPI draft.ContentAPI, umbrellaAPI suggestions.UmbrellaAPI, job queue.Job) error {
+	ctx := context.Background()
+
+	for _, uuid := range job.UUIDs {
+		result := queue.Result{JobID: job.ID, UUID: uuid}
+
+		content, err := contentAPI.FetchDraftContent(ctx, uuid)
+		if err != nil {
+			result.Error = err.Error()
+		} else if content == nil {
+			result.Error = "draft content not found"
+		} else if suggestionsResponse, err := umbrellaAPI.FetchSuggestions(ctx, content, nil); err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Suggestions = suggestionsResponse
+		}
+
+		if err := broker.PublishResult(ctx, result); err != nil {
+			log.WithError(err).WithField("jobId", job.ID).WithField("uuid", uuid).Error("Failed to publish suggestions job result")
+			return err
+		}
+	}
+
+	return nil
+}
+
+// brokerProvider adapts a queue.Broker to process.Provider, so Run closes its
+// connection on shutdown.
+type brokerProvider struct {
+	broker queue.Broker
+}
+
+func (b brokerProvider) Name() string { return "broker" }
+func (b brokerProvider) Close() error { return b.broker.Close() }
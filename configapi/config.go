@@ -0,0 +1,62 @@
+// Package configapi exposes a GET/PUT /__config/{key} endpoint backed by a
+// Provider of JSON documents, so suggestion filters and content-type
+// allow-lists can be changed at runtime without a restart.
+package configapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Well-known configuration document keys.
+const (
+	KeyForbiddenHostnames  = "forbidden-hostnames"
+	KeyAllowedContentTypes = "allowed-content-types"
+	KeySuggestionFilters   = "suggestion-filters"
+)
+
+// SuggestionFilter identifies suggestions that should be dropped before being
+// returned to the caller.
+type SuggestionFilter struct {
+	Predicate string `json:"predicate,omitempty"`
+	Type      string `json:"type,omitempty"`
+}
+
+// Snapshot is a point-in-time, typed view of the well-known configuration documents.
+type Snapshot struct {
+	ForbiddenHostnames  []string           `json:"forbidden-hostnames"`
+	AllowedContentTypes []string           `json:"allowed-content-types"`
+	SuggestionFilters   []SuggestionFilter `json:"suggestion-filters"`
+}
+
+// validatePayload checks value against the shape expected for one of the
+// well-known configuration keys, so a JSON-valid but wrongly-shaped write
+// (e.g. an object where an array is expected) is rejected instead of being
+// silently dropped to its zero value the next time Snapshot is read. Keys
+// this package doesn't know about accept any valid JSON document.
+func validatePayload(key string, value json.RawMessage) error {
+	switch key {
+	case KeyForbiddenHostnames, KeyAllowedContentTypes:
+		var v []string
+		if err := json.Unmarshal(value, &v); err != nil {
+			return fmt.Errorf("%s must be a JSON array of strings: %v", key, err)
+		}
+	case KeySuggestionFilters:
+		var v []SuggestionFilter
+		if err := json.Unmarshal(value, &v); err != nil {
+			return fmt.Errorf("%s must be a JSON array of suggestion filters: %v", key, err)
+		}
+	}
+
+	return nil
+}
+
+// Provider exposes the current configuration as a typed Snapshot, lets callers
+// read/write individual JSON documents by key, and notifies subscribers
+// whenever a document changes.
+type Provider interface {
+	Snapshot() Snapshot
+	Get(key string) (json.RawMessage, bool)
+	Put(key string, value json.RawMessage) error
+	Subscribe() <-chan string
+}
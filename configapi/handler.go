@@ -0,0 +1,68 @@
+package configapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Handler serves GET/PUT /__config/{key} against a Provider.
+type Handler struct {
+	provider Provider
+}
+
+// NewHandler returns a Handler backed by provider.
+func NewHandler(provider Provider) *Handler {
+	return &Handler{provider: provider}
+}
+
+// Register wires the /{key} routes onto router. router is expected to already
+// be scoped to the /__config prefix, e.g. by mounting it as a PathPrefix
+// subrouter, so that callers can apply middleware (such as auth) to just
+// these routes.
+func (h *Handler) Register(router *mux.Router) {
+	router.HandleFunc("/{key}", h.get).Methods(http.MethodGet)
+	router.HandleFunc("/{key}", h.put).Methods(http.MethodPut)
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	value, found := h.provider.Get(key)
+	if !found {
+		http.Error(w, "no config found for key: "+key, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(value)
+}
+
+func (h *Handler) put(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	if !json.Valid(body) {
+		http.Error(w, "request body must be valid JSON", http.StatusBadRequest)
+		return
+	}
+
+	if err := validatePayload(key, body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.provider.Put(key, body); err != nil {
+		http.Error(w, "failed to persist config", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
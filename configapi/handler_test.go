@@ -0,0 +1,77 @@
+package configapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestHandler(t *testing.T) (*Handler, *mux.Router) {
+	provider, err := NewProvider("")
+	assert.NoError(t, err)
+
+	handler := NewHandler(provider)
+	router := mux.NewRouter()
+	handler.Register(router)
+
+	return handler, router
+}
+
+func TestHandler_Put_RejectsMalformedJSON(t *testing.T) {
+	_, router := newTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/"+KeyAllowedContentTypes, strings.NewReader(`not json`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestHandler_Put_RejectsWrongShapeForWellKnownKey(t *testing.T) {
+	_, router := newTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/"+KeyAllowedContentTypes, strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), KeyAllowedContentTypes)
+}
+
+func TestHandler_Put_AcceptsValidShapeForWellKnownKey(t *testing.T) {
+	handler, router := newTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/"+KeyAllowedContentTypes, strings.NewReader(`["article"]`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	value, found := handler.provider.Get(KeyAllowedContentTypes)
+	assert.True(t, found)
+	assert.JSONEq(t, `["article"]`, string(value))
+}
+
+func TestHandler_Put_AllowsAnyValidJSONForUnknownKey(t *testing.T) {
+	_, router := newTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodPut, "/some-other-key", strings.NewReader(`{"anything":true}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestHandler_Get_NotFound(t *testing.T) {
+	_, router := newTestHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/does-not-exist", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
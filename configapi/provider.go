@@ -0,0 +1,130 @@
+package configapi
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+type provider struct {
+	mu          sync.RWMutex
+	documents   map[string]json.RawMessage
+	configFile  string
+	subscribers []chan string
+}
+
+// NewProvider returns a Provider seeded with empty values for the well-known
+// configuration keys. When configFile is non-empty, any previously persisted
+// documents are loaded from it on startup, and every Put is persisted back to it.
+func NewProvider(configFile string) (Provider, error) {
+	p := &provider{
+		documents:  defaultDocuments(),
+		configFile: configFile,
+	}
+
+	if configFile == "" {
+		return p, nil
+	}
+
+	bytes, err := ioutil.ReadFile(configFile)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var persisted map[string]json.RawMessage
+	if err := json.Unmarshal(bytes, &persisted); err != nil {
+		return nil, err
+	}
+
+	for key, value := range persisted {
+		p.documents[key] = value
+	}
+
+	return p, nil
+}
+
+func defaultDocuments() map[string]json.RawMessage {
+	return map[string]json.RawMessage{
+		KeyForbiddenHostnames:  json.RawMessage("[]"),
+		KeyAllowedContentTypes: json.RawMessage("[]"),
+		KeySuggestionFilters:   json.RawMessage("[]"),
+	}
+}
+
+func (p *provider) Get(key string) (json.RawMessage, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	value, found := p.documents[key]
+	return value, found
+}
+
+func (p *provider) Put(key string, value json.RawMessage) error {
+	p.mu.Lock()
+	p.documents[key] = value
+
+	// persist while still holding the lock: releasing it first would let two
+	// concurrent Puts race to write the file, and the one holding the older
+	// snapshot could finish last and clobber the newer write.
+	var persistErr error
+	if p.configFile != "" {
+		persistErr = p.persist(p.documents)
+	}
+
+	subscribers := append([]chan string{}, p.subscribers...)
+	p.mu.Unlock()
+
+	if persistErr != nil {
+		return persistErr
+	}
+
+	for _, subscriber := range subscribers {
+		select {
+		case subscriber <- key:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// Subscribe returns a channel on which the key of every future Put is sent.
+// Sends are non-blocking, so a slow subscriber only misses notifications, it
+// never blocks a writer.
+func (p *provider) Subscribe() <-chan string {
+	ch := make(chan string, 1)
+
+	p.mu.Lock()
+	p.subscribers = append(p.subscribers, ch)
+	p.mu.Unlock()
+
+	return ch
+}
+
+func (p *provider) Snapshot() Snapshot {
+	var snapshot Snapshot
+
+	if raw, found := p.Get(KeyForbiddenHostnames); found {
+		json.Unmarshal(raw, &snapshot.ForbiddenHostnames)
+	}
+	if raw, found := p.Get(KeyAllowedContentTypes); found {
+		json.Unmarshal(raw, &snapshot.AllowedContentTypes)
+	}
+	if raw, found := p.Get(KeySuggestionFilters); found {
+		json.Unmarshal(raw, &snapshot.SuggestionFilters)
+	}
+
+	return snapshot
+}
+
+func (p *provider) persist(documents map[string]json.RawMessage) error {
+	bytes, err := json.Marshal(documents)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p.configFile, bytes, 0644)
+}
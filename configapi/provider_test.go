@@ -0,0 +1,128 @@
+package configapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProvider_GetUnknownKey(t *testing.T) {
+	provider, err := NewProvider("")
+	assert.NoError(t, err)
+
+	_, found := provider.Get("does-not-exist")
+	assert.False(t, found)
+}
+
+func TestProvider_PutThenGet(t *testing.T) {
+	provider, err := NewProvider("")
+	assert.NoError(t, err)
+
+	err = provider.Put(KeyForbiddenHostnames, json.RawMessage(`["example.com"]`))
+	assert.NoError(t, err)
+
+	value, found := provider.Get(KeyForbiddenHostnames)
+	assert.True(t, found)
+	assert.JSONEq(t, `["example.com"]`, string(value))
+
+	snapshot := provider.Snapshot()
+	assert.Equal(t, []string{"example.com"}, snapshot.ForbiddenHostnames)
+}
+
+func TestProvider_Subscribe(t *testing.T) {
+	provider, err := NewProvider("")
+	assert.NoError(t, err)
+
+	updates := provider.Subscribe()
+
+	err = provider.Put(KeyAllowedContentTypes, json.RawMessage(`["article"]`))
+	assert.NoError(t, err)
+
+	assert.Equal(t, KeyAllowedContentTypes, <-updates)
+}
+
+func TestProvider_ConcurrentReadWrite(t *testing.T) {
+	provider, err := NewProvider("")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			provider.Put(KeySuggestionFilters, json.RawMessage(`[{"type":"ORGANISATION"}]`))
+		}()
+		go func() {
+			defer wg.Done()
+			provider.Get(KeySuggestionFilters)
+		}()
+	}
+	wg.Wait()
+
+	value, found := provider.Get(KeySuggestionFilters)
+	assert.True(t, found)
+	assert.JSONEq(t, `[{"type":"ORGANISATION"}]`, string(value))
+}
+
+func TestProvider_PersistenceRoundTrip(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configapi-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config.json")
+
+	provider, err := NewProvider(configFile)
+	assert.NoError(t, err)
+
+	err = provider.Put(KeyForbiddenHostnames, json.RawMessage(`["bad.example.com"]`))
+	assert.NoError(t, err)
+
+	reloaded, err := NewProvider(configFile)
+	assert.NoError(t, err)
+
+	value, found := reloaded.Get(KeyForbiddenHostnames)
+	assert.True(t, found)
+	assert.JSONEq(t, `["bad.example.com"]`, string(value))
+}
+
+// TestProvider_ConcurrentWriteWithPersistenceDoesNotLoseUpdates guards against
+// the TOCTOU race where persisting outside the lock let an older snapshot's
+// write finish after a newer one and clobber it on disk.
+func TestProvider_ConcurrentWriteWithPersistenceDoesNotLoseUpdates(t *testing.T) {
+	dir, err := ioutil.TempDir("", "configapi-test-concurrent")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	configFile := filepath.Join(dir, "config.json")
+
+	provider, err := NewProvider(configFile)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			provider.Put(KeySuggestionFilters, json.RawMessage(fmt.Sprintf(`[{"type":"T%d"}]`, i)))
+		}()
+	}
+	wg.Wait()
+
+	inMemory, found := provider.Get(KeySuggestionFilters)
+	assert.True(t, found)
+
+	reloaded, err := NewProvider(configFile)
+	assert.NoError(t, err)
+
+	onDisk, found := reloaded.Get(KeySuggestionFilters)
+	assert.True(t, found)
+
+	assert.JSONEq(t, string(inMemory), string(onDisk))
+}
@@ -1,192 +1,203 @@
 package main
 
 import (
+	"context"
 	"net/http"
-	"os"
-	"os/signal"
-	"sync"
-	"syscall"
+	"strings"
 	"time"
 
-	"github.com/Financial-Times/api-endpoint"
+	"github.com/Financial-Times/draft-content-suggestions/auth"
+	"github.com/Financial-Times/draft-content-suggestions/configapi"
 	"github.com/Financial-Times/draft-content-suggestions/draft"
-	"github.com/Financial-Times/draft-content-suggestions/health"
+	"github.com/Financial-Times/draft-content-suggestions/process"
 	"github.com/Financial-Times/draft-content-suggestions/suggestions"
 	"github.com/Financial-Times/go-ft-http/fthttp"
 	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
-	"github.com/Financial-Times/http-handlers-go/httphandlers"
-	status "github.com/Financial-Times/service-status-go/httphandlers"
 	"github.com/gorilla/mux"
-	"github.com/jawher/mow.cli"
-	"github.com/rcrowley/go-metrics"
 	log "github.com/sirupsen/logrus"
 )
 
-const appDescription = "Provides suggestions for draft content."
-
 func main() {
-	app := cli.App("draft-content-suggestions", appDescription)
-
-	appSystemCode := app.String(cli.StringOpt{
-		Name:   "app-system-code",
-		Value:  "draft-content-suggestions",
-		Desc:   "System Code of the application",
-		EnvVar: "APP_SYSTEM_CODE",
-	})
-
-	appName := app.String(cli.StringOpt{
-		Name:   "app-name",
-		Value:  "Draft Content Suggestions",
-		Desc:   "Application name",
-		EnvVar: "APP_NAME",
-	})
-
-	port := app.String(cli.StringOpt{
-		Name:   "port",
-		Value:  "8080",
-		Desc:   "Port to listen on",
-		EnvVar: "APP_PORT",
-	})
-
-	apiYml := app.String(cli.StringOpt{
-		Name:   "api-yml",
-		Value:  "./api.yml",
-		Desc:   "Location of the OpenAPI YML file.",
-		EnvVar: "API_YML",
-	})
-
-	draftContentEndpoint := app.String(cli.StringOpt{
-		Name:   "draft-content-endpoint",
-		Value:  "http://draft-content-public-read:8080/content",
-		Desc:   "Endpoint for Draft Content API",
-		EnvVar: "DRAFT_CONTENT_ENDPOINT",
-	})
-
-	draftContentGtgEndpoint := app.String(cli.StringOpt{
-		Name:   "draft-content-gtg-endpoint",
-		Value:  "http://draft-content-public-read:8080/__gtg",
-		Desc:   "GTG Endpoint for Draft Content API",
-		EnvVar: "DRAFT_CONTENT_GTG_ENDPOINT",
-	})
-
-	suggestionsEndpoint := app.String(cli.StringOpt{
-		Name:   "suggestions-umbrella-endpoint",
-		Value:  "http://test.api.ft.com/content/suggest",
-		Desc:   "Endpoint for Suggestions Umbrella",
-		EnvVar: "SUGGESTIONS_ENDPOINT",
-	})
-	suggestionsGtgEndpoint := app.String(cli.StringOpt{
-		Name:   "suggestions-umbrella-gtg-endpoint",
-		Value:  "http://test.api.ft.com/content/suggest/__gtg",
-		Desc:   "Endpoint for Suggestions Umbrella",
-		EnvVar: "SUGGESTIONS_GTG_ENDPOINT",
-	})
-
-	suggestionsAPIKey := app.String(cli.StringOpt{
-		Name:   "suggestions-api-key",
-		Value:  "",
-		Desc:   "API key to access Suggestions Umbrella",
-		EnvVar: "SUGGESTIONS_API_KEY",
-	})
-
-	log.SetFormatter(&log.JSONFormatter{})
-	log.SetLevel(log.InfoLevel)
-	log.Infof("[Startup] draft-content-suggestions is starting ")
+	process.Run(newSuggestionsProcess())
+}
 
+// suggestionsProcess is the process.Process implementation for this service:
+// it fetches draft content, requests suggestions for it from the Suggestions
+// Umbrella, and returns the (filtered) result.
+type suggestionsProcess struct {
+	draftContentEndpoint    *string
+	draftContentGtgEndpoint *string
+	suggestionsEndpoint     *string
+	suggestionsGtgEndpoint  *string
+	suggestionsAuthMode     *string
+	suggestionsAPIKey       *string
+	suggestionsUsername     *string
+	suggestionsPassword     *string
+	suggestionsXPolicies    *string
+	apiSigningKey           *string
+	configFile              *string
+
+	contentAPI     draft.ContentAPI
+	umbrellaAPI    suggestions.UmbrellaAPI
+	configProvider configapi.Provider
+}
+
+func newSuggestionsProcess() *suggestionsProcess {
+	return &suggestionsProcess{}
+}
+
+func (p *suggestionsProcess) Name() string {
+	return "draft-content-suggestions"
+}
+
+func (p *suggestionsProcess) Description() string {
+	return "Provides suggestions for draft content."
+}
+
+func (p *suggestionsProcess) Flags() []process.Flag {
+	return []process.Flag{
+		{Name: "draft-content-endpoint", Value: "http://draft-content-public-read:8080/content",
+			Desc: "Endpoint for Draft Content API", EnvVar: "DRAFT_CONTENT_ENDPOINT", Target: &p.draftContentEndpoint},
+		{Name: "draft-content-gtg-endpoint", Value: "http://draft-content-public-read:8080/__gtg",
+			Desc: "GTG Endpoint for Draft Content API", EnvVar: "DRAFT_CONTENT_GTG_ENDPOINT", Target: &p.draftContentGtgEndpoint},
+		{Name: "suggestions-umbrella-endpoint", Value: "http://test.api.ft.com/content/suggest",
+			Desc: "Endpoint for Suggestions Umbrella", EnvVar: "SUGGESTIONS_ENDPOINT", Target: &p.suggestionsEndpoint},
+		{Name: "suggestions-umbrella-gtg-endpoint", Value: "http://test.api.ft.com/content/suggest/__gtg",
+			Desc: "GTG Endpoint for Suggestions Umbrella", EnvVar: "SUGGESTIONS_GTG_ENDPOINT", Target: &p.suggestionsGtgEndpoint},
+		{Name: "auth-mode", Value: string(suggestions.AuthModeAPIKey),
+			Desc: "Authentication mode for Suggestions Umbrella requests: api-key or basic", EnvVar: "SUGGESTIONS_AUTH_MODE", Target: &p.suggestionsAuthMode},
+		{Name: "suggestions-api-key", Value: "",
+			Desc: "API key to access Suggestions Umbrella (used when auth-mode is api-key)", EnvVar: "SUGGESTIONS_API_KEY", Target: &p.suggestionsAPIKey},
+		{Name: "suggestions-username", Value: "",
+			Desc: "Username to access Suggestions Umbrella (used when auth-mode is basic)", EnvVar: "SUGGESTIONS_USERNAME", Target: &p.suggestionsUsername},
+		{Name: "suggestions-password", Value: "",
+			Desc: "Password to access Suggestions Umbrella (used when auth-mode is basic)", EnvVar: "SUGGESTIONS_PASSWORD", Target: &p.suggestionsPassword},
+		{Name: "suggestions-x-policies", Value: "",
+			Desc: "Comma-separated list of x-policy values to forward on every Suggestions Umbrella request", EnvVar: "SUGGESTIONS_X_POLICIES", Target: &p.suggestionsXPolicies},
+		{Name: "api-signing-key", Value: "",
+			Desc: "Shared secret used to validate the HS256 signature of API bearer tokens. Auth is disabled if empty.", EnvVar: "API_SIGNING_KEY", Target: &p.apiSigningKey},
+		{Name: "config-file", Value: "",
+			Desc: "File to persist runtime config (forbidden hostnames, allowed content types, suggestion filters) to. Config is in-memory only if empty.", EnvVar: "CONFIG_FILE", Target: &p.configFile},
+	}
+}
+
+func (p *suggestionsProcess) Provide(ctx context.Context) ([]process.Provider, error) {
 	client := fthttp.NewClientBuilder().
 		WithTimeout(10*time.Second).
-		WithSysInfo("PAC", *appSystemCode).
+		WithSysInfo("PAC", p.Name()).
 		Build()
 
 	umbrellaClient := fthttp.NewClientBuilder().
 		WithTimeout(10*time.Second).
-		WithSysInfo("PAC", *appSystemCode).
+		WithSysInfo("PAC", p.Name()).
 		WithLogging(log.StandardLogger()).
 		Build()
 
-	umbrellaAPI, err := suggestions.NewUmbrellaAPI(*suggestionsEndpoint, *suggestionsGtgEndpoint, *suggestionsAPIKey, umbrellaClient)
-
-	if err != nil {
-		log.WithError(err).Error("Suggestions Umbrella API error, exiting ...")
-		return
+	var xPolicies []string
+	if *p.suggestionsXPolicies != "" {
+		xPolicies = strings.Split(*p.suggestionsXPolicies, ",")
 	}
 
-	contentAPI, err := draft.NewContentAPI(*draftContentEndpoint, *draftContentGtgEndpoint, client)
-
+	umbrellaAPI, err := suggestions.NewUmbrellaAPI(*p.suggestionsEndpoint, *p.suggestionsGtgEndpoint, suggestions.AuthMode(*p.suggestionsAuthMode),
+		*p.suggestionsAPIKey, *p.suggestionsUsername, *p.suggestionsPassword, xPolicies, umbrellaClient)
 	if err != nil {
-		log.WithError(err).Error("Draft Content API error, exiting ...")
-		return
+		return nil, err
 	}
 
-	app.Action = func() {
-		log.Infof("System code: %s, App Name: %s, Port: %s", *appSystemCode, *appName, *port)
-
-		go func() {
-			serveEndpoints(*appSystemCode, *appName, *port, apiYml, requestHandler{contentAPI, umbrellaAPI})
-		}()
-
-		waitForSignal()
+	contentAPI, err := draft.NewContentAPI(*p.draftContentEndpoint, *p.draftContentGtgEndpoint, client)
+	if err != nil {
+		return nil, err
 	}
 
-	err = app.Run(os.Args)
+	configProvider, err := configapi.NewProvider(*p.configFile)
 	if err != nil {
-		log.WithError(err).Error("draft-content-suggestions could not start!")
-		return
+		return nil, err
 	}
-}
 
-func serveEndpoints(appSystemCode string, appName string, port string, apiYml *string, requestHandler requestHandler) {
-	healthService := health.NewHealthService(appSystemCode, appName, appDescription,
-		requestHandler.dca, requestHandler.sua)
+	p.umbrellaAPI = umbrellaAPI
+	p.contentAPI = contentAPI
+	p.configProvider = configProvider
 
-	serveMux := http.NewServeMux()
+	return []process.Provider{
+		namedProvider("draft-content-api"),
+		namedProvider("suggestions-umbrella"),
+		namedProvider("config-provider"),
+	}, nil
+}
 
-	serveMux.HandleFunc(health.DefaultHealthPath, http.HandlerFunc(fthealth.Handler(healthService.Health())))
-	serveMux.HandleFunc(status.GTGPath, status.NewGoodToGoHandler(healthService.GTG))
-	serveMux.HandleFunc(status.BuildInfoPath, status.BuildInfoHandler)
+func (p *suggestionsProcess) Subscribers() []process.SubscriberDef {
+	return []process.SubscriberDef{
+		{Name: "config-change-logger", Run: p.logConfigChanges},
+	}
+}
 
-	if apiYml != nil {
-		apiEndpoint, err := api.NewAPIEndpointForFile(*apiYml)
-		if err != nil {
-			log.WithError(err).WithField("file", apiYml).Warn("Failed to serve the API Endpoint for this service. Please validate the file exists, and that it fits the OpenAPI specification.")
-		} else {
-			serveMux.HandleFunc(api.DefaultPath, apiEndpoint.ServeHTTP)
+// logConfigChanges logs every key updated via the config API, so that changes
+// to forbidden hostnames, allowed content types and suggestion filters show
+// up in the application logs without needing to poll /__config.
+func (p *suggestionsProcess) logConfigChanges(ctx context.Context) error {
+	updates := p.configProvider.Subscribe()
+
+	for {
+		select {
+		case key := <-updates:
+			log.WithField("key", key).Info("Runtime config updated")
+		case <-ctx.Done():
+			return nil
 		}
 	}
+}
 
-	servicesRouter := mux.NewRouter()
-	servicesRouter.HandleFunc("/drafts/content/{uuid}/suggestions",
-		requestHandler.draftContentSuggestionsRequest).Methods("GET")
-
-	monitoringRouter := httphandlers.TransactionAwareRequestLoggingHandler(log.StandardLogger(), servicesRouter)
-	monitoringRouter = httphandlers.HTTPMetricsHandler(metrics.DefaultRegistry, monitoringRouter)
-
-	serveMux.Handle("/", monitoringRouter)
-
-	server := &http.Server{Addr: ":" + port, Handler: serveMux}
-
-	wg := sync.WaitGroup{}
+func (p *suggestionsProcess) HTTPHandler() http.Handler {
+	handler := requestHandler{p.contentAPI, p.umbrellaAPI, p.configProvider}
 
-	wg.Add(1)
-	go func() {
-		if err := server.ListenAndServe(); err != nil {
-			log.WithError(err).Info("HTTP server closing with message")
-		}
-		wg.Done()
-	}()
+	router := mux.NewRouter()
 
-	waitForSignal()
-	log.Infof("[Shutdown] draft-content-suggestions is shutting down")
+	var suggestionsHandler http.Handler = http.HandlerFunc(handler.draftContentSuggestionsRequest)
+	if *p.apiSigningKey != "" {
+		suggestionsHandler = auth.NewMiddleware([]byte(*p.apiSigningKey))(suggestionsHandler)
+	}
+	router.Handle("/drafts/content/{uuid}/suggestions", suggestionsHandler).Methods(http.MethodGet)
 
-	if err := server.Close(); err != nil {
-		log.WithError(err).Error("Unable to stop http server")
+	configRouter := router.PathPrefix("/__config").Subrouter()
+	if *p.apiSigningKey != "" {
+		configRouter.Use(auth.NewMiddleware([]byte(*p.apiSigningKey)))
 	}
+	configapi.NewHandler(p.configProvider).Register(configRouter)
 
-	wg.Wait()
+	return router
 }
 
-func waitForSignal() {
-	ch := make(chan os.Signal)
-	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
-	<-ch
+func (p *suggestionsProcess) HealthChecks() []fthealth.Check {
+	return []fthealth.Check{
+		{
+			ID:               "check-draft-content-api-health",
+			Name:             "Check connectivity to draft-content-api",
+			Severity:         1,
+			BusinessImpact:   "Suggestions cannot be generated for draft content.",
+			TechnicalSummary: "Draft Content Suggestions API cannot connect to draft-content-api.",
+			PanicGuide:       "https://runbooks.ftops.tech/draft-content-suggestions",
+			Checker: func() (string, error) {
+				return p.contentAPI.IsGTG(context.Background())
+			},
+		},
+		{
+			ID:               "check-suggestions-umbrella-health",
+			Name:             "Check connectivity to the Suggestions Umbrella",
+			Severity:         1,
+			BusinessImpact:   "Suggestions cannot be generated for draft content.",
+			TechnicalSummary: "Draft Content Suggestions API cannot connect to the Suggestions Umbrella.",
+			PanicGuide:       "https://runbooks.ftops.tech/draft-content-suggestions",
+			Checker: func() (string, error) {
+				return p.umbrellaAPI.IsGTG(context.Background())
+			},
+		},
+	}
 }
+
+// namedProvider adapts a resource with no explicit lifecycle (our upstream
+// HTTP clients) to process.Provider, so it still shows up by name if closing
+// it ever becomes necessary.
+type namedProvider string
+
+func (n namedProvider) Name() string { return string(n) }
+func (n namedProvider) Close() error { return nil }
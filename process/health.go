@@ -0,0 +1,39 @@
+package process
+
+import (
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/Financial-Times/service-status-go/gtg"
+)
+
+type healthService struct {
+	systemCode  string
+	name        string
+	description string
+	checks      []fthealth.Check
+}
+
+func (h *healthService) health() fthealth.HC {
+	return fthealth.HC{
+		SystemCode:  h.systemCode,
+		Name:        h.name,
+		Description: h.description,
+		Checks:      h.checks,
+	}
+}
+
+func (h *healthService) gtg() gtg.Status {
+	checkers := make([]gtg.StatusChecker, len(h.checks))
+
+	for i, check := range h.checks {
+		check := check
+		checkers[i] = func() gtg.Status {
+			msg, err := check.Checker()
+			if err != nil {
+				return gtg.Status{GoodToGo: false, Message: err.Error()}
+			}
+			return gtg.Status{GoodToGo: true, Message: msg}
+		}
+	}
+
+	return gtg.FailFastParallelCheck(checkers)()
+}
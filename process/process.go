@@ -0,0 +1,64 @@
+// Package process centralises the CLI parsing, structured logging, standard
+// admin endpoints, and graceful shutdown that every binary in this repo
+// needs, so a new service only has to implement the Process interface.
+package process
+
+import (
+	"context"
+	"net/http"
+
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+)
+
+// Flag is a single CLI flag a Process wants Run to register before parsing
+// os.Args. Target is bound to the parsed value once Run has registered it
+// with the underlying CLI app.
+type Flag struct {
+	Name   string
+	Value  string
+	Desc   string
+	EnvVar string
+	Target **string
+}
+
+// Provider is a named resource a Process constructs in Provide (an upstream
+// API client, a broker connection, ...) that Run releases on shutdown.
+type Provider interface {
+	Name() string
+	Close() error
+}
+
+// SubscriberDef is a background goroutine - typically a queue consumer - that
+// Run starts alongside the HTTP server and stops when the process receives a
+// shutdown signal.
+type SubscriberDef struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Process is everything Run needs to serve a standard FT microservice: its
+// CLI flags, the providers it constructs, any background subscribers, its
+// business HTTP handler, and its health checks.
+type Process interface {
+	// Name identifies the process; it is used as the CLI app name, the
+	// default app-system-code/app-name, and in shutdown log lines.
+	Name() string
+
+	// Flags are registered on the CLI app before os.Args is parsed.
+	Flags() []Flag
+
+	// Provide constructs the process's upstream clients and other resources
+	// once flags have been parsed. The returned Providers are closed, in
+	// order, during shutdown.
+	Provide(ctx context.Context) ([]Provider, error)
+
+	// Subscribers are started once Provide has returned and run for the
+	// lifetime of the process.
+	Subscribers() []SubscriberDef
+
+	// HTTPHandler serves the process's business endpoints, mounted under "/".
+	HTTPHandler() http.Handler
+
+	// HealthChecks contributes to the standard /__health and /__gtg endpoints.
+	HealthChecks() []fthealth.Check
+}
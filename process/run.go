@@ -0,0 +1,165 @@
+package process
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	api "github.com/Financial-Times/api-endpoint"
+	fthealth "github.com/Financial-Times/go-fthealth/v1_1"
+	"github.com/Financial-Times/http-handlers-go/httphandlers"
+	status "github.com/Financial-Times/service-status-go/httphandlers"
+	cli "github.com/jawher/mow.cli"
+	metrics "github.com/rcrowley/go-metrics"
+	log "github.com/sirupsen/logrus"
+)
+
+// Run parses os.Args against p's flags plus the standard app-system-code,
+// app-name, port and api-yml flags, then serves p's HTTPHandler alongside the
+// standard /__health, /__gtg, /__build-info and OpenAPI endpoints until it
+// receives SIGINT or SIGTERM, at which point it stops the HTTP server, its
+// subscribers, and closes every Provider p returned from Provide.
+func Run(p Process) {
+	app := cli.App(p.Name(), description(p))
+
+	appSystemCode := app.String(cli.StringOpt{
+		Name:   "app-system-code",
+		Value:  p.Name(),
+		Desc:   "System Code of the application",
+		EnvVar: "APP_SYSTEM_CODE",
+	})
+
+	appName := app.String(cli.StringOpt{
+		Name:   "app-name",
+		Value:  p.Name(),
+		Desc:   "Application name",
+		EnvVar: "APP_NAME",
+	})
+
+	port := app.String(cli.StringOpt{
+		Name:   "port",
+		Value:  "8080",
+		Desc:   "Port to listen on",
+		EnvVar: "APP_PORT",
+	})
+
+	apiYml := app.String(cli.StringOpt{
+		Name:   "api-yml",
+		Value:  "./api.yml",
+		Desc:   "Location of the OpenAPI YML file.",
+		EnvVar: "API_YML",
+	})
+
+	for _, flag := range p.Flags() {
+		*flag.Target = app.String(cli.StringOpt{
+			Name:   flag.Name,
+			Value:  flag.Value,
+			Desc:   flag.Desc,
+			EnvVar: flag.EnvVar,
+		})
+	}
+
+	log.SetFormatter(&log.JSONFormatter{})
+	log.SetLevel(log.InfoLevel)
+
+	app.Action = func() {
+		log.Infof("[Startup] %s is starting", p.Name())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		providers, err := p.Provide(ctx)
+		if err != nil {
+			log.WithError(err).Error("Failed to initialise providers, exiting ...")
+			return
+		}
+
+		health := &healthService{
+			systemCode:  *appSystemCode,
+			name:        *appName,
+			description: description(p),
+			checks:      p.HealthChecks(),
+		}
+
+		serveMux := http.NewServeMux()
+		serveMux.HandleFunc("/__health", http.HandlerFunc(fthealth.Handler(health.health())))
+		serveMux.HandleFunc(status.GTGPath, status.NewGoodToGoHandler(health.gtg))
+		serveMux.HandleFunc(status.BuildInfoPath, status.BuildInfoHandler)
+
+		if apiEndpoint, err := api.NewAPIEndpointForFile(*apiYml); err != nil {
+			log.WithError(err).WithField("file", *apiYml).Warn("Failed to serve the API Endpoint for this service. Please validate the file exists, and that it fits the OpenAPI specification.")
+		} else {
+			serveMux.HandleFunc(api.DefaultPath, apiEndpoint.ServeHTTP)
+		}
+
+		monitoringHandler := httphandlers.TransactionAwareRequestLoggingHandler(log.StandardLogger(), p.HTTPHandler())
+		monitoringHandler = httphandlers.HTTPMetricsHandler(metrics.DefaultRegistry, monitoringHandler)
+		serveMux.Handle("/", monitoringHandler)
+
+		server := &http.Server{Addr: ":" + *port, Handler: serveMux}
+
+		var wg sync.WaitGroup
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := server.ListenAndServe(); err != nil {
+				log.WithError(err).Info("HTTP server closing with message")
+			}
+		}()
+
+		for _, subscriber := range p.Subscribers() {
+			wg.Add(1)
+			go func(s SubscriberDef) {
+				defer wg.Done()
+				if err := s.Run(ctx); err != nil && ctx.Err() == nil {
+					log.WithError(err).WithField("subscriber", s.Name).Error("Subscriber stopped unexpectedly")
+				}
+			}(subscriber)
+		}
+
+		waitForSignal()
+		log.Infof("[Shutdown] %s is shutting down", p.Name())
+
+		cancel()
+
+		if err := server.Close(); err != nil {
+			log.WithError(err).Error("Unable to stop http server")
+		}
+
+		for _, provider := range providers {
+			if err := provider.Close(); err != nil {
+				log.WithError(err).WithField("provider", provider.Name()).Error("Failed to close provider")
+			}
+		}
+
+		wg.Wait()
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.WithError(err).Errorf("%s could not start!", p.Name())
+	}
+}
+
+func waitForSignal() {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+	<-ch
+}
+
+// Describer is implemented by a Process that wants a human-readable summary
+// shown in --help output and in the /__health description field, rather than
+// having its Name() repeated there.
+type Describer interface {
+	Description() string
+}
+
+func description(p Process) string {
+	if d, ok := p.(Describer); ok {
+		return d.Description()
+	}
+	return p.Name()
+}
@@ -0,0 +1,137 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/streadway/amqp"
+)
+
+const (
+	jobsQueueName    = "suggestions-jobs"
+	resultsQueueName = "suggestions-results"
+)
+
+// NewAMQPBroker connects to the RabbitMQ instance at url and declares the
+// durable queues used to carry jobs and results between the scheduler and
+// worker processes.
+func NewAMQPBroker(url string) (Broker, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, err
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	for _, name := range []string{jobsQueueName, resultsQueueName} {
+		if _, err := channel.QueueDeclare(name, true, false, false, false, nil); err != nil {
+			channel.Close()
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return &amqpBroker{conn: conn, channel: channel}, nil
+}
+
+type amqpBroker struct {
+	conn    *amqp.Connection
+	channel *amqp.Channel
+}
+
+func (a *amqpBroker) PublishJob(ctx context.Context, job Job) error {
+	return a.publish(jobsQueueName, job)
+}
+
+func (a *amqpBroker) ConsumeJobs(ctx context.Context) (<-chan JobDelivery, error) {
+	deliveries, err := a.channel.Consume(jobsQueueName, "", false, false, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make(chan JobDelivery)
+	go func() {
+		defer close(jobs)
+		for delivery := range deliveries {
+			delivery := delivery
+
+			var job Job
+			if err := json.Unmarshal(delivery.Body, &job); err != nil {
+				log.WithError(err).Error("Dropping unparseable suggestions job")
+				delivery.Ack(false)
+				continue
+			}
+
+			jobs <- JobDelivery{
+				Job:  job,
+				Ack:  func() { delivery.Ack(false) },
+				Nack: func() { delivery.Nack(false, true) },
+			}
+		}
+	}()
+
+	return jobs, nil
+}
+
+func (a *amqpBroker) PublishResult(ctx context.Context, result Result) error {
+	return a.publish(resultsQueueName, result)
+}
+
+func (a *amqpBroker) ConsumeResults(ctx context.Context) (<-chan ResultDelivery, error) {
+	deliveries, err := a.channel.Consume(resultsQueueName, "", false, false, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(chan ResultDelivery)
+	go func() {
+		defer close(results)
+		for delivery := range deliveries {
+			delivery := delivery
+
+			var result Result
+			if err := json.Unmarshal(delivery.Body, &result); err != nil {
+				log.WithError(err).Error("Dropping unparseable suggestions job result")
+				delivery.Ack(false)
+				continue
+			}
+
+			results <- ResultDelivery{
+				Result: result,
+				Ack:    func() { delivery.Ack(false) },
+				Nack:   func() { delivery.Nack(false, true) },
+			}
+		}
+	}()
+
+	return results, nil
+}
+
+func (a *amqpBroker) publish(queueName string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return a.channel.Publish("", queueName, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Body:         body,
+	})
+}
+
+func (a *amqpBroker) IsConnected() bool {
+	return !a.conn.IsClosed()
+}
+
+func (a *amqpBroker) Close() error {
+	if err := a.channel.Close(); err != nil {
+		return err
+	}
+	return a.conn.Close()
+}
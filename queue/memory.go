@@ -0,0 +1,102 @@
+package queue
+
+import "context"
+
+// NewMemoryBroker returns a Broker backed by in-process buffered channels.
+// It is intended for tests and for running the scheduler/worker pair without
+// a real RabbitMQ instance.
+func NewMemoryBroker(bufferSize int) Broker {
+	return &memoryBroker{
+		jobs:    make(chan Job, bufferSize),
+		results: make(chan Result, bufferSize),
+	}
+}
+
+type memoryBroker struct {
+	jobs    chan Job
+	results chan Result
+}
+
+func (m *memoryBroker) PublishJob(ctx context.Context, job Job) error {
+	select {
+	case m.jobs <- job:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *memoryBroker) ConsumeJobs(ctx context.Context) (<-chan JobDelivery, error) {
+	deliveries := make(chan JobDelivery)
+	go func() {
+		defer close(deliveries)
+		for job := range m.jobs {
+			job := job
+			deliveries <- JobDelivery{
+				Job:  job,
+				Ack:  func() {},
+				Nack: func() { m.requeueJob(job) },
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+// requeueJob re-enqueues a Nacked job for another consumer to pick up. It
+// drops the job rather than blocking if the buffer is full, since this broker
+// only exists to exercise the scheduler/worker pair without a real RabbitMQ
+// instance.
+func (m *memoryBroker) requeueJob(job Job) {
+	select {
+	case m.jobs <- job:
+	default:
+	}
+}
+
+func (m *memoryBroker) PublishResult(ctx context.Context, result Result) error {
+	select {
+	case m.results <- result:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (m *memoryBroker) ConsumeResults(ctx context.Context) (<-chan ResultDelivery, error) {
+	deliveries := make(chan ResultDelivery)
+	go func() {
+		defer close(deliveries)
+		for result := range m.results {
+			result := result
+			deliveries <- ResultDelivery{
+				Result: result,
+				Ack:    func() {},
+				Nack:   func() { m.requeueResult(result) },
+			}
+		}
+	}()
+
+	return deliveries, nil
+}
+
+// requeueResult re-enqueues a Nacked result, dropping it rather than blocking
+// if the buffer is full, for the same reason as requeueJob.
+func (m *memoryBroker) requeueResult(result Result) {
+	select {
+	case m.results <- result:
+	default:
+	}
+}
+
+// IsConnected always reports true: this broker has no underlying network
+// connection to lose.
+func (m *memoryBroker) IsConnected() bool {
+	return true
+}
+
+func (m *memoryBroker) Close() error {
+	close(m.jobs)
+	close(m.results)
+	return nil
+}
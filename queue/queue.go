@@ -0,0 +1,59 @@
+// Package queue abstracts the message broker used to distribute bulk
+// suggestions jobs to worker processes and collect their results, so callers
+// can re-annotate large historical corpora without holding synchronous HTTP
+// connections open.
+package queue
+
+import "context"
+
+// Job asks a worker to fetch and generate suggestions for a batch of draft
+// content UUIDs.
+type Job struct {
+	ID    string   `json:"id"`
+	UUIDs []string `json:"uuids"`
+}
+
+// Result is the outcome of processing a single UUID within a Job.
+type Result struct {
+	JobID       string `json:"jobId"`
+	UUID        string `json:"uuid"`
+	Suggestions []byte `json:"suggestions,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// JobDelivery is a Job consumed off the broker, paired with the
+// acknowledgement the consumer must make exactly once after it has finished
+// with the job: Ack once its outcome has been durably published, or Nack to
+// have the broker redeliver it.
+type JobDelivery struct {
+	Job
+	Ack  func()
+	Nack func()
+}
+
+// ResultDelivery is a Result consumed off the broker, paired with the
+// acknowledgement the consumer must make exactly once after it has recorded
+// the result.
+type ResultDelivery struct {
+	Result
+	Ack  func()
+	Nack func()
+}
+
+// Broker publishes jobs for workers to consume, and carries the results back
+// to whoever is waiting on them. Deliveries are not considered settled until
+// acknowledged, so a consumer that crashes mid-job leaves it to be
+// redelivered rather than silently dropping it.
+type Broker interface {
+	PublishJob(ctx context.Context, job Job) error
+	ConsumeJobs(ctx context.Context) (<-chan JobDelivery, error)
+
+	PublishResult(ctx context.Context, result Result) error
+	ConsumeResults(ctx context.Context) (<-chan ResultDelivery, error)
+
+	// IsConnected reports whether the broker's underlying connection is
+	// still usable, so callers can surface it on a health/gtg check.
+	IsConnected() bool
+
+	Close() error
+}
@@ -0,0 +1,86 @@
+package queue
+
+import "sync"
+
+// JobStatus is the lifecycle state of a submitted Job.
+type JobStatus string
+
+const (
+	// JobStatusPending means results are still outstanding for at least one UUID in the job.
+	JobStatusPending JobStatus = "pending"
+	// JobStatusComplete means results have been recorded for every UUID in the job.
+	JobStatusComplete JobStatus = "complete"
+)
+
+// JobState is the current, polled view of a submitted Job.
+type JobState struct {
+	Status  JobStatus `json:"status"`
+	Results []Result  `json:"results"`
+}
+
+// Store tracks submitted jobs and the results that have come back for them,
+// so a caller can poll for completion by job ID.
+type Store interface {
+	Put(job Job)
+	AddResult(result Result)
+	Get(jobID string) (JobState, bool)
+}
+
+// NewMemoryStore returns a Store backed by an in-process map. It is suitable
+// for a single scheduler instance; a multi-instance deployment would back
+// this with a shared store instead.
+func NewMemoryStore() Store {
+	return &memoryStore{jobs: make(map[string]*jobRecord)}
+}
+
+type jobRecord struct {
+	remaining map[string]bool
+	results   []Result
+}
+
+type memoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]*jobRecord
+}
+
+func (s *memoryStore) Put(job Job) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	remaining := make(map[string]bool, len(job.UUIDs))
+	for _, uuid := range job.UUIDs {
+		remaining[uuid] = true
+	}
+
+	s.jobs[job.ID] = &jobRecord{remaining: remaining}
+}
+
+func (s *memoryStore) AddResult(result Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, found := s.jobs[result.JobID]
+	if !found {
+		return
+	}
+
+	delete(record.remaining, result.UUID)
+	record.results = append(record.results, result)
+}
+
+func (s *memoryStore) Get(jobID string) (JobState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, found := s.jobs[jobID]
+	if !found {
+		return JobState{}, false
+	}
+
+	status := JobStatusComplete
+	if len(record.remaining) > 0 {
+		status = JobStatusPending
+	}
+
+	return JobState{Status: status, Results: record.results}, true
+}
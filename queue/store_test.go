@@ -0,0 +1,61 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStore_PendingUntilAllResultsIn(t *testing.T) {
+	store := NewMemoryStore()
+	job := Job{ID: "job-1", UUIDs: []string{"uuid-1", "uuid-2"}}
+	store.Put(job)
+
+	state, found := store.Get("job-1")
+	assert.True(t, found)
+	assert.Equal(t, JobStatusPending, state.Status)
+	assert.Empty(t, state.Results)
+
+	store.AddResult(Result{JobID: "job-1", UUID: "uuid-1", Suggestions: []byte("{}")})
+
+	state, found = store.Get("job-1")
+	assert.True(t, found)
+	assert.Equal(t, JobStatusPending, state.Status)
+	assert.Len(t, state.Results, 1)
+
+	store.AddResult(Result{JobID: "job-1", UUID: "uuid-2", Suggestions: []byte("{}")})
+
+	state, found = store.Get("job-1")
+	assert.True(t, found)
+	assert.Equal(t, JobStatusComplete, state.Status)
+	assert.Len(t, state.Results, 2)
+}
+
+func TestMemoryStore_UnknownJob(t *testing.T) {
+	store := NewMemoryStore()
+
+	_, found := store.Get("does-not-exist")
+	assert.False(t, found)
+}
+
+func TestMemoryStore_ConcurrentAccess(t *testing.T) {
+	store := NewMemoryStore()
+	job := Job{ID: "job-1", UUIDs: []string{"uuid-1", "uuid-2", "uuid-3"}}
+	store.Put(job)
+
+	var wg sync.WaitGroup
+	for _, uuid := range job.UUIDs {
+		wg.Add(1)
+		go func(uuid string) {
+			defer wg.Done()
+			store.AddResult(Result{JobID: "job-1", UUID: uuid, Suggestions: []byte("{}")})
+		}(uuid)
+	}
+	wg.Wait()
+
+	state, found := store.Get("job-1")
+	assert.True(t, found)
+	assert.Equal(t, JobStatusComplete, state.Status)
+	assert.Len(t, state.Results, 3)
+}
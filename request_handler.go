@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Financial-Times/draft-content-suggestions/commons"
+	"github.com/Financial-Times/draft-content-suggestions/configapi"
+	"github.com/Financial-Times/draft-content-suggestions/draft"
+	"github.com/Financial-Times/draft-content-suggestions/suggestions"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+type requestHandler struct {
+	dca    draft.ContentAPI
+	sua    suggestions.UmbrellaAPI
+	config configapi.Provider
+}
+
+func (rh requestHandler) draftContentSuggestionsRequest(w http.ResponseWriter, r *http.Request) {
+	uuid := mux.Vars(r)["uuid"]
+	ctx := commons.NewContextFromRequest(r)
+
+	if err := commons.ValidateUUID(uuid); err != nil {
+		commons.WriteJSONMessage(w, http.StatusBadRequest, "invalid uuid: "+uuid)
+		return
+	}
+
+	content, err := rh.dca.FetchDraftContent(ctx, uuid)
+	if err != nil {
+		log.WithError(err).WithField("uuid", uuid).Error("Failed to fetch draft content")
+		commons.WriteJSONMessage(w, http.StatusServiceUnavailable, "failed to fetch draft content")
+		return
+	}
+
+	if content == nil {
+		commons.WriteJSONMessage(w, http.StatusNotFound, "draft content not found for uuid: "+uuid)
+		return
+	}
+
+	snapshot := rh.config.Snapshot()
+
+	var meta draftContentMeta
+	json.Unmarshal(content, &meta)
+
+	if meta.isForbidden(snapshot.ForbiddenHostnames) {
+		commons.WriteJSONMessage(w, http.StatusNotFound, "draft content not found for uuid: "+uuid)
+		return
+	}
+
+	if !meta.isAllowed(snapshot.AllowedContentTypes) {
+		commons.WriteJSONMessage(w, http.StatusNotFound, "draft content not found for uuid: "+uuid)
+		return
+	}
+
+	suggestions, err := rh.sua.FetchSuggestions(ctx, content, inboundXPolicy(r))
+	if err != nil {
+		log.WithError(err).WithField("uuid", uuid).Error("Failed to fetch suggestions")
+		commons.WriteJSONMessage(w, http.StatusServiceUnavailable, "failed to fetch suggestions")
+		return
+	}
+
+	filtered, err := filterSuggestions(suggestions, snapshot.SuggestionFilters)
+	if err != nil {
+		log.WithError(err).WithField("uuid", uuid).Error("Failed to apply suggestion filters, returning suggestions unfiltered")
+		filtered = suggestions
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(filtered)
+}
+
+// draftContentMeta is the subset of a draft content document needed to apply
+// the forbidden-hostnames and allowed-content-types config.
+type draftContentMeta struct {
+	Type         string `json:"type"`
+	WebURL       string `json:"webUrl"`
+	CanonicalURL string `json:"canonicalUrl"`
+}
+
+func (m draftContentMeta) isForbidden(forbiddenHostnames []string) bool {
+	for _, hostname := range forbiddenHostnames {
+		if hostname == "" {
+			continue
+		}
+		if hostMatches(m.WebURL, hostname) || hostMatches(m.CanonicalURL, hostname) {
+			return true
+		}
+	}
+	return false
+}
+
+// hostMatches reports whether rawURL's host is forbiddenHostname or a
+// subdomain of it, so configuring "ft.com" as forbidden also catches
+// "www.ft.com" without matching unrelated domains such as
+// "not-ft.com.example.org" the way a raw substring check would.
+func hostMatches(rawURL string, forbiddenHostname string) bool {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+
+	host := parsed.Hostname()
+	return host == forbiddenHostname || strings.HasSuffix(host, "."+forbiddenHostname)
+}
+
+func (m draftContentMeta) isAllowed(allowedContentTypes []string) bool {
+	if len(allowedContentTypes) == 0 {
+		return true
+	}
+
+	for _, contentType := range allowedContentTypes {
+		if contentType == m.Type {
+			return true
+		}
+	}
+	return false
+}
+
+// suggestionsDocument mirrors the shape returned by the Suggestions Umbrella,
+// keeping each suggestion as a raw JSON value so filtering doesn't need to
+// know its full structure.
+type suggestionsDocument struct {
+	Suggestions []json.RawMessage `json:"suggestions"`
+}
+
+type suggestionMeta struct {
+	Predicate string `json:"predicate"`
+	Type      string `json:"type"`
+}
+
+// filterSuggestions drops any suggestion matching a configured denylist entry
+// before it is returned to the caller. It is a no-op when no filters are configured.
+func filterSuggestions(body []byte, filters []configapi.SuggestionFilter) ([]byte, error) {
+	if len(filters) == 0 {
+		return body, nil
+	}
+
+	var doc suggestionsDocument
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, err
+	}
+
+	kept := make([]json.RawMessage, 0, len(doc.Suggestions))
+	for _, raw := range doc.Suggestions {
+		var meta suggestionMeta
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, err
+		}
+
+		if !isDenied(meta, filters) {
+			kept = append(kept, raw)
+		}
+	}
+
+	doc.Suggestions = kept
+	return json.Marshal(doc)
+}
+
+func isDenied(meta suggestionMeta, filters []configapi.SuggestionFilter) bool {
+	for _, filter := range filters {
+		if filter.Predicate != "" && filter.Predicate != meta.Predicate {
+			continue
+		}
+		if filter.Type != "" && filter.Type != meta.Type {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// inboundXPolicy extracts any x-policy header set by the caller, so it can be
+// propagated through to the Suggestions Umbrella alongside the configured policies.
+func inboundXPolicy(r *http.Request) []string {
+	header := r.Header.Get("x-policy")
+
+	if header == "" {
+		return nil
+	}
+
+	return strings.Split(header, ",")
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Financial-Times/draft-content-suggestions/configapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftContentMeta_IsForbidden(t *testing.T) {
+	meta := draftContentMeta{WebURL: "https://www.example.com/content/some-uuid"}
+
+	assert.True(t, meta.isForbidden([]string{"example.com"}))
+	assert.False(t, meta.isForbidden([]string{"other.com"}))
+	assert.False(t, meta.isForbidden(nil))
+}
+
+func TestDraftContentMeta_IsForbidden_ExactHostMatch(t *testing.T) {
+	meta := draftContentMeta{WebURL: "https://example.com/content/some-uuid"}
+
+	assert.True(t, meta.isForbidden([]string{"example.com"}))
+}
+
+func TestDraftContentMeta_IsForbidden_DoesNotMatchUnrelatedDomainBySubstring(t *testing.T) {
+	meta := draftContentMeta{WebURL: "https://not-example.com.other.org/content/some-uuid"}
+
+	assert.False(t, meta.isForbidden([]string{"example.com"}))
+}
+
+func TestDraftContentMeta_IsForbidden_MatchesCanonicalURL(t *testing.T) {
+	meta := draftContentMeta{CanonicalURL: "https://www.example.com/content/some-uuid"}
+
+	assert.True(t, meta.isForbidden([]string{"example.com"}))
+}
+
+func TestDraftContentMeta_IsForbidden_InvalidURLIsNotForbidden(t *testing.T) {
+	meta := draftContentMeta{WebURL: "://not-a-url"}
+
+	assert.False(t, meta.isForbidden([]string{"example.com"}))
+}
+
+func TestDraftContentMeta_IsAllowed(t *testing.T) {
+	meta := draftContentMeta{Type: "article"}
+
+	assert.True(t, meta.isAllowed(nil))
+	assert.True(t, meta.isAllowed([]string{"article", "content-package"}))
+	assert.False(t, meta.isAllowed([]string{"content-package"}))
+}
+
+func TestFilterSuggestions_NoFilters(t *testing.T) {
+	body := []byte(`{"suggestions":[{"predicate":"about","type":"PERSON"}]}`)
+
+	filtered, err := filterSuggestions(body, nil)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(body), string(filtered))
+}
+
+func TestFilterSuggestions_DropsMatchingType(t *testing.T) {
+	body := []byte(`{"suggestions":[{"predicate":"about","type":"PERSON"},{"predicate":"mentions","type":"ORGANISATION"}]}`)
+	filters := []configapi.SuggestionFilter{{Type: "ORGANISATION"}}
+
+	filtered, err := filterSuggestions(body, filters)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"suggestions":[{"predicate":"about","type":"PERSON"}]}`, string(filtered))
+}
+
+func TestFilterSuggestions_DropsMatchingPredicateAndType(t *testing.T) {
+	body := []byte(`{"suggestions":[{"predicate":"about","type":"PERSON"},{"predicate":"about","type":"ORGANISATION"}]}`)
+	filters := []configapi.SuggestionFilter{{Predicate: "about", Type: "ORGANISATION"}}
+
+	filtered, err := filterSuggestions(body, filters)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"suggestions":[{"predicate":"about","type":"PERSON"}]}`, string(filtered))
+}
@@ -0,0 +1,154 @@
+package suggestions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/Financial-Times/draft-content-suggestions/commons"
+)
+
+// AuthMode selects how outbound requests to the Suggestions Umbrella are authenticated.
+type AuthMode string
+
+const (
+	// AuthModeAPIKey authenticates using the legacy X-Api-Key header.
+	AuthModeAPIKey AuthMode = "api-key"
+	// AuthModeBasic authenticates using HTTP Basic Auth, following the pattern
+	// adopted by draft-content-api.
+	AuthModeBasic AuthMode = "basic"
+)
+
+const xPolicyHeader = "X-Policy"
+
+// UmbrellaAPI for accessing the Suggestions Umbrella endpoint
+type UmbrellaAPI interface {
+	FetchSuggestions(ctx context.Context, content []byte, xPolicy []string) (suggestions []byte, err error)
+	commons.Endpoint
+}
+
+type umbrellaAPI struct {
+	endpoint       string
+	healthEndpoint string
+	httpClient     *http.Client
+
+	authMode  AuthMode
+	apiKey    string
+	username  string
+	password  string
+	xPolicies []string
+}
+
+// NewUmbrellaAPI builds an UmbrellaAPI. When authMode is AuthModeBasic, username
+// and password are required and are sent as HTTP Basic Auth credentials; otherwise
+// apiKey is sent via the legacy X-Api-Key header. xPolicies are joined and sent on
+// every outbound request, in addition to any policies supplied per-call.
+func NewUmbrellaAPI(endpoint string, healthEndpoint string, authMode AuthMode, apiKey string, username string, password string, xPolicies []string, httpClient *http.Client) (UmbrellaAPI, error) {
+
+	if authMode == AuthModeBasic && (username == "" || password == "") {
+		return nil, errors.New("suggestions-username and suggestions-password are required when auth-mode is basic")
+	}
+
+	umbrellaAPI := &umbrellaAPI{
+		endpoint:       endpoint,
+		healthEndpoint: healthEndpoint,
+		httpClient:     httpClient,
+		authMode:       authMode,
+		apiKey:         apiKey,
+		username:       username,
+		password:       password,
+		xPolicies:      xPolicies,
+	}
+
+	err := umbrellaAPI.IsValid()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return umbrellaAPI, nil
+}
+
+func (u *umbrellaAPI) FetchSuggestions(ctx context.Context, content []byte, xPolicy []string) ([]byte, error) {
+
+	request, err := http.NewRequest(http.MethodPost, u.endpoint, bytes.NewReader(content))
+
+	if err != nil {
+		return nil, err
+	}
+
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/json")
+	u.setAuth(request)
+	u.setXPolicy(request, xPolicy)
+
+	response, err := u.httpClient.Do(request)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("suggestions umbrella returned a non-200 HTTP status: %v", response.StatusCode)
+	}
+
+	return ioutil.ReadAll(response.Body)
+}
+
+// setXPolicy forwards the configured x-policy values together with any policies
+// supplied for this call (e.g. propagated from the caller's inbound request),
+// so downstream policy-based filtering works end-to-end.
+func (u *umbrellaAPI) setXPolicy(request *http.Request, xPolicy []string) {
+	policies := append(append([]string{}, u.xPolicies...), xPolicy...)
+
+	if len(policies) > 0 {
+		request.Header.Set(xPolicyHeader, strings.Join(policies, ","))
+	}
+}
+
+func (u *umbrellaAPI) setAuth(request *http.Request) {
+	if u.authMode == AuthModeBasic {
+		request.SetBasicAuth(u.username, u.password)
+		return
+	}
+
+	request.Header.Set("X-Api-Key", u.apiKey)
+}
+
+func (u *umbrellaAPI) Endpoint() string {
+	return u.endpoint
+}
+
+func (u *umbrellaAPI) IsValid() error {
+	return commons.ValidateEndpoint(u.endpoint)
+}
+
+func (u *umbrellaAPI) IsGTG(ctx context.Context) (string, error) {
+	request, err := http.NewRequest(http.MethodGet, u.healthEndpoint, nil)
+
+	if err != nil {
+		return "", err
+	}
+
+	u.setAuth(request)
+
+	response, err := u.httpClient.Do(request.WithContext(ctx))
+
+	if err != nil {
+		return "", err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", errors.New("suggestions-umbrella endpoint is unhealthy")
+	}
+
+	return "suggestions-umbrella is healthy", nil
+}
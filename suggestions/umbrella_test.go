@@ -0,0 +1,106 @@
+package suggestions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewUmbrellaAPI_BasicAuthRequiresCredentials(t *testing.T) {
+	_, err := NewUmbrellaAPI("http://example.com", "http://example.com/__gtg", AuthModeBasic, "", "", "", nil, http.DefaultClient)
+	assert.EqualError(t, err, "suggestions-username and suggestions-password are required when auth-mode is basic")
+
+	_, err = NewUmbrellaAPI("http://example.com", "http://example.com/__gtg", AuthModeBasic, "", "a-user", "", nil, http.DefaultClient)
+	assert.Error(t, err)
+
+	_, err = NewUmbrellaAPI("http://example.com", "http://example.com/__gtg", AuthModeBasic, "", "a-user", "a-password", nil, http.DefaultClient)
+	assert.NoError(t, err)
+}
+
+func TestUmbrellaAPI_FetchSuggestions_APIKeyAuth(t *testing.T) {
+	var gotAPIKey string
+	var gotAuthHeaderSet bool
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAPIKey = r.Header.Get("X-Api-Key")
+		_, gotAuthHeaderSet = r.Header["Authorization"]
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer testServer.Close()
+
+	api, err := NewUmbrellaAPI(testServer.URL, testServer.URL+"/__gtg", AuthModeAPIKey, "a-key", "", "", nil, http.DefaultClient)
+	assert.NoError(t, err)
+
+	_, err = api.FetchSuggestions(context.Background(), []byte(`{}`), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "a-key", gotAPIKey)
+	assert.False(t, gotAuthHeaderSet)
+}
+
+func TestUmbrellaAPI_FetchSuggestions_BasicAuth(t *testing.T) {
+	var gotUsername, gotPassword string
+	var gotOk bool
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUsername, gotPassword, gotOk = r.BasicAuth()
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer testServer.Close()
+
+	api, err := NewUmbrellaAPI(testServer.URL, testServer.URL+"/__gtg", AuthModeBasic, "", "a-user", "a-password", nil, http.DefaultClient)
+	assert.NoError(t, err)
+
+	_, err = api.FetchSuggestions(context.Background(), []byte(`{}`), nil)
+	assert.NoError(t, err)
+
+	assert.True(t, gotOk)
+	assert.Equal(t, "a-user", gotUsername)
+	assert.Equal(t, "a-password", gotPassword)
+}
+
+func TestUmbrellaAPI_FetchSuggestions_SetsXPolicy(t *testing.T) {
+	var gotXPolicy string
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotXPolicy = r.Header.Get(xPolicyHeader)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer testServer.Close()
+
+	api, err := NewUmbrellaAPI(testServer.URL, testServer.URL+"/__gtg", AuthModeAPIKey, "a-key", "", "", []string{"CONTENT_RECOMMENDATION"}, http.DefaultClient)
+	assert.NoError(t, err)
+
+	_, err = api.FetchSuggestions(context.Background(), []byte(`{}`), []string{"INTERNAL_UNSTABLE"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "CONTENT_RECOMMENDATION,INTERNAL_UNSTABLE", gotXPolicy)
+}
+
+func TestUmbrellaAPI_FetchSuggestions_NoXPolicyHeaderWhenEmpty(t *testing.T) {
+	var gotXPolicy string
+	var gotXPolicySet bool
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotXPolicy = r.Header.Get(xPolicyHeader)
+		_, gotXPolicySet = r.Header[xPolicyHeader]
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer testServer.Close()
+
+	api, err := NewUmbrellaAPI(testServer.URL, testServer.URL+"/__gtg", AuthModeAPIKey, "a-key", "", "", nil, http.DefaultClient)
+	assert.NoError(t, err)
+
+	_, err = api.FetchSuggestions(context.Background(), []byte(`{}`), nil)
+	assert.NoError(t, err)
+
+	assert.False(t, gotXPolicySet)
+	assert.Empty(t, gotXPolicy)
+}